@@ -0,0 +1,57 @@
+// Copyright 2020 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package domain implements `kn admin domain`, which manages the custom
+// domains routes are served on, stored either in the config-domain
+// ConfigMap (standalone installs) or the KnativeServing custom resource
+// (operator installs).
+package domain
+
+import (
+	"github.com/spf13/cobra"
+
+	"knative.dev/kn-plugin-admin/pkg"
+	"knative.dev/kn-plugin-admin/pkg/completion"
+)
+
+// NewDomainCommand creates the `domain` parent command and wires in its
+// subcommands.
+func NewDomainCommand(p *pkg.AdminParams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "domain",
+		Short: "Manage Knative route domains",
+	}
+
+	cmd.AddCommand(NewDomainSetCommand(p))
+	cmd.AddCommand(NewDomainListCommand(p))
+	cmd.AddCommand(NewDomainUnsetCommand(p))
+
+	return cmd
+}
+
+// registerCustomDomainCompletion wires shell completion for --custom-domain
+// into cmd, suggesting the domains already configured on the cluster.
+func registerCustomDomainCompletion(cmd *cobra.Command, p *pkg.AdminParams) {
+	_ = cmd.RegisterFlagCompletionFunc("custom-domain", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return completion.CustomDomains(p)
+	})
+}
+
+// registerSelectorCompletion wires shell completion for --selector into cmd,
+// suggesting label keys found on Knative Services in the cluster.
+func registerSelectorCompletion(cmd *cobra.Command, p *pkg.AdminParams) {
+	_ = cmd.RegisterFlagCompletionFunc("selector", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return completion.SelectorKeys(p)
+	})
+}
@@ -0,0 +1,73 @@
+// Copyright 2020 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+const (
+	dryRunNone   = "none"
+	dryRunClient = "client"
+	dryRunServer = "server"
+)
+
+// normalizeDryRun validates the `--dry-run` flag value, defaulting an empty
+// value to dryRunNone.
+func normalizeDryRun(dryRun string) (string, error) {
+	switch dryRun {
+	case "":
+		return dryRunNone, nil
+	case dryRunNone, dryRunClient, dryRunServer:
+		return dryRun, nil
+	default:
+		return "", fmt.Errorf("unsupported --dry-run value %q, supported values are: none, client, server", dryRun)
+	}
+}
+
+// validateOutput validates the `-o`/`--output` flag value.
+func validateOutput(output string) error {
+	switch output {
+	case "", "yaml", "json":
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format %q, supported formats are: yaml, json", output)
+	}
+}
+
+// printObject writes obj to cmd's output in the requested format.
+func printObject(cmd *cobra.Command, obj interface{}, output string) error {
+	switch output {
+	case "yaml":
+		b, err := sigsyaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		cmd.Print(string(b))
+	case "json":
+		b, err := json.MarshalIndent(obj, "", "  ")
+		if err != nil {
+			return err
+		}
+		cmd.Println(string(b))
+	default:
+		return fmt.Errorf("unsupported output format %q, supported formats are: yaml, json", output)
+	}
+	return nil
+}
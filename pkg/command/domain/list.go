@@ -0,0 +1,192 @@
+// Copyright 2020 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"knative.dev/kn-plugin-admin/pkg"
+)
+
+// domainEntry is one row of `domain list`, independent of whether it was
+// read from a ConfigMap or a KnativeServing resource.
+type domainEntry struct {
+	Domain   string            `json:"domain" yaml:"domain"`
+	Selector map[string]string `json:"selector,omitempty" yaml:"selector,omitempty"`
+	Default  bool              `json:"default" yaml:"default"`
+}
+
+// NewDomainListCommand creates the `domain list` command, which prints the
+// domains currently configured, in table, JSON or YAML form.
+func NewDomainListCommand(p *pkg.AdminParams) *cobra.Command {
+	var (
+		output             string
+		knativeServingName string
+		knativeServingNS   string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List the custom domains configured for Knative route",
+		Example: `  kn admin domain list`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var data map[string]string
+			if p.InstallationMethod == pkg.InstallationMethodOperator {
+				d, err := domainDataFromOperator(p, knativeServingName, knativeServingNS)
+				if err != nil {
+					return err
+				}
+				data = d
+			} else {
+				d, err := domainDataFromConfigMap(p)
+				if err != nil {
+					return err
+				}
+				data = d
+			}
+
+			entries, err := toDomainEntries(data)
+			if err != nil {
+				return err
+			}
+
+			switch output {
+			case "", "table":
+				return printDomainTable(cmd, entries)
+			case "json":
+				b, err := json.MarshalIndent(entries, "", "  ")
+				if err != nil {
+					return err
+				}
+				cmd.Println(string(b))
+			case "yaml":
+				b, err := yaml.Marshal(entries)
+				if err != nil {
+					return err
+				}
+				cmd.Print(string(b))
+			default:
+				return fmt.Errorf("unsupported output format %q, supported formats are: table, json, yaml", output)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table, json or yaml")
+	cmd.Flags().StringVar(&knativeServingName, "knative-serving-name", "", "Name of the KnativeServing resource to read (operator installs only); auto-detected if there is exactly one")
+	cmd.Flags().StringVar(&knativeServingNS, "knative-serving-namespace", "", "Namespace of the KnativeServing resource to read (operator installs only); if unset, defaults to knative-serving when --knative-serving-name is given and otherwise searches all namespaces")
+
+	return cmd
+}
+
+// domainDataFromConfigMap returns the raw config-domain ConfigMap data.
+func domainDataFromConfigMap(p *pkg.AdminParams) (map[string]string, error) {
+	client, err := p.ClientSet()
+	if err != nil {
+		return nil, err
+	}
+
+	cm, err := client.CoreV1().ConfigMaps(knativeServing).Get(context.TODO(), configDomain, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ConfigMap %q in namespace %q: %w", configDomain, knativeServing, err)
+	}
+	return cm.Data, nil
+}
+
+// domainDataFromOperator returns the raw spec.config.domain data of the
+// selected KnativeServing resource.
+func domainDataFromOperator(p *pkg.AdminParams, name, namespace string) (map[string]string, error) {
+	dc, err := p.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+
+	ks, err := getKnativeServing(dc, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	data, _, err := unstructured.NestedStringMap(ks.Object, "spec", "config", "domain")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec.config.domain of KnativeServing %q: %w", ks.GetName(), err)
+	}
+	return data, nil
+}
+
+// toDomainEntries parses config-domain-style raw data into domainEntry,
+// sorted by domain name for stable output.
+func toDomainEntries(data map[string]string) ([]domainEntry, error) {
+	domains := make([]string, 0, len(data))
+	for d := range data {
+		domains = append(domains, d)
+	}
+	sort.Strings(domains)
+
+	entries := make([]domainEntry, 0, len(domains))
+	for _, d := range domains {
+		selector, err := parseDomainValue(data[d])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse domain %q: %w", d, err)
+		}
+		entries = append(entries, domainEntry{
+			Domain:   d,
+			Selector: selector,
+			Default:  len(selector) == 0,
+		})
+	}
+	return entries, nil
+}
+
+// parseDomainValue is the inverse of domainValue: it recovers the selector
+// from a config-domain-style value, which is empty for the default domain.
+func parseDomainValue(value string) (map[string]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	var cfg domainConfig
+	if err := yaml.Unmarshal([]byte(value), &cfg); err != nil {
+		return nil, err
+	}
+	return cfg.Selector, nil
+}
+
+// printDomainTable renders entries as a simple aligned table.
+func printDomainTable(cmd *cobra.Command, entries []domainEntry) error {
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 8, 3, ' ', 0)
+	fmt.Fprintln(w, "DOMAIN\tSELECTOR\tDEFAULT")
+	for _, e := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%t\n", e.Domain, formatSelectorOrDash(e.Selector), e.Default)
+	}
+	return w.Flush()
+}
+
+// formatSelectorOrDash is like formatSelector but prints "-" for the
+// default domain instead of an empty "[]".
+func formatSelectorOrDash(selector map[string]string) string {
+	if len(selector) == 0 {
+		return "-"
+	}
+	return formatSelector(selector)
+}
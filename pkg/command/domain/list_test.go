@@ -0,0 +1,127 @@
+// Copyright 2020 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/kn-plugin-admin/pkg"
+
+	"knative.dev/kn-plugin-admin/pkg/testutil"
+)
+
+func TestNewDomainListCommand(t *testing.T) {
+
+	t.Run("kubectl context is not set", func(t *testing.T) {
+		p := testutil.NewTestAdminWithoutKubeConfig()
+		p.InstallationMethod = pkg.InstallationMethodStandalone
+		cmd := NewDomainListCommand(p)
+		_, err := testutil.ExecuteCommand(cmd)
+		assert.Error(t, err, testutil.ErrNoKubeConfiguration)
+	})
+
+	t.Run("config map not exist", func(t *testing.T) {
+		p, client := testutil.NewTestAdminParams()
+		assert.Check(t, client != nil)
+		cmd := NewDomainListCommand(p)
+		_, err := testutil.ExecuteCommand(cmd)
+		assert.ErrorContains(t, err, "failed to get ConfigMap", err)
+	})
+
+	t.Run("table output", func(t *testing.T) {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      configDomain,
+				Namespace: knativeServing,
+			},
+			Data: map[string]string{
+				"foo.bar":     "",
+				"test.domain": "selector:\n  app: test\n",
+			},
+		}
+		p, client := testutil.NewTestAdminParams(cm)
+		assert.Check(t, client != nil)
+		cmd := NewDomainListCommand(p)
+
+		o, err := testutil.ExecuteCommand(cmd)
+		assert.NilError(t, err)
+		assert.Check(t, strings.Contains(o, "foo.bar"), "expected output to list foo.bar, got %q", o)
+		assert.Check(t, strings.Contains(o, "test.domain"), "expected output to list test.domain, got %q", o)
+		assert.Check(t, strings.Contains(o, "[app=test]"), "expected output to show the selector, got %q", o)
+	})
+
+	t.Run("json output", func(t *testing.T) {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      configDomain,
+				Namespace: knativeServing,
+			},
+			Data: map[string]string{
+				"foo.bar": "",
+			},
+		}
+		p, client := testutil.NewTestAdminParams(cm)
+		assert.Check(t, client != nil)
+		cmd := NewDomainListCommand(p)
+
+		o, err := testutil.ExecuteCommand(cmd, "-o", "json")
+		assert.NilError(t, err)
+		assert.Check(t, strings.Contains(o, `"domain": "foo.bar"`), "expected JSON output, got %q", o)
+	})
+
+	t.Run("operator mode with no KnativeServing resource", func(t *testing.T) {
+		p, _ := testutil.NewTestAdminParamsWithKnativeServing()
+		cmd := NewDomainListCommand(p)
+
+		_, err := testutil.ExecuteCommand(cmd)
+		assert.ErrorContains(t, err, "no KnativeServing resource found", err)
+	})
+
+	t.Run("list in operator mode", func(t *testing.T) {
+		ks := newKnativeServing("knative-serving", knativeServing, map[string]string{
+			"foo.bar":     "",
+			"test.domain": "selector:\n  app: test\n",
+		})
+		p, dynamicClient := testutil.NewTestAdminParamsWithKnativeServing(ks)
+		assert.Check(t, dynamicClient != nil)
+		cmd := NewDomainListCommand(p)
+
+		o, err := testutil.ExecuteCommand(cmd)
+		assert.NilError(t, err)
+		assert.Check(t, strings.Contains(o, "foo.bar"), "expected output to list foo.bar, got %q", o)
+		assert.Check(t, strings.Contains(o, "test.domain"), "expected output to list test.domain, got %q", o)
+		assert.Check(t, strings.Contains(o, "[app=test]"), "expected output to show the selector, got %q", o)
+	})
+
+	t.Run("unsupported output format", func(t *testing.T) {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      configDomain,
+				Namespace: knativeServing,
+			},
+			Data: make(map[string]string),
+		}
+		p, client := testutil.NewTestAdminParams(cm)
+		assert.Check(t, client != nil)
+		cmd := NewDomainListCommand(p)
+
+		_, err := testutil.ExecuteCommand(cmd, "-o", "csv")
+		assert.ErrorContains(t, err, "unsupported output format", err)
+	})
+}
@@ -0,0 +1,141 @@
+// Copyright 2020 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"knative.dev/kn-plugin-admin/pkg"
+)
+
+// knativeServingResource is the GroupVersionResource of the operator's
+// KnativeServing custom resource, which carries spec.config.domain for
+// operator-managed installs.
+var knativeServingResource = schema.GroupVersionResource{
+	Group:    "operator.knative.dev",
+	Version:  "v1beta1",
+	Resource: "knativeservings",
+}
+
+// setDomainOperator applies domain/selector to the spec.config.domain of
+// the KnativeServing resource selected by name/namespace, using the same
+// merge/no-op semantics as setDomainConfigMap.
+func setDomainOperator(p *pkg.AdminParams, cmd *cobra.Command, domain string, selector map[string]string, name, namespace, dryRun, output string) error {
+	dc, err := p.DynamicClient()
+	if err != nil {
+		return err
+	}
+
+	ks, err := getKnativeServing(dc, name, namespace)
+	if err != nil {
+		return err
+	}
+
+	value, err := domainValue(selector)
+	if err != nil {
+		return err
+	}
+
+	domainCfg, _, err := unstructured.NestedStringMap(ks.Object, "spec", "config", "domain")
+	if err != nil {
+		return fmt.Errorf("failed to read spec.config.domain of KnativeServing %q: %w", ks.GetName(), err)
+	}
+
+	if existing, ok := domainCfg[domain]; ok && existing == value {
+		return nil
+	}
+
+	if len(selector) == 0 {
+		// A domain with no selector is the cluster's default domain, so
+		// setting one replaces whatever default was previously configured
+		// rather than piling up alongside it. Selector-scoped domains are
+		// additive and are kept.
+		for d, v := range domainCfg {
+			if v == "" {
+				delete(domainCfg, d)
+			}
+		}
+	}
+	if domainCfg == nil {
+		domainCfg = map[string]string{}
+	}
+	domainCfg[domain] = value
+
+	if err := unstructured.SetNestedStringMap(ks.Object, domainCfg, "spec", "config", "domain"); err != nil {
+		return fmt.Errorf("failed to set spec.config.domain of KnativeServing %q: %w", ks.GetName(), err)
+	}
+
+	if dryRun != dryRunClient {
+		updateOpts := metav1.UpdateOptions{}
+		if dryRun == dryRunServer {
+			updateOpts.DryRun = []string{metav1.DryRunAll}
+		}
+		updated, err := dc.Resource(knativeServingResource).Namespace(ks.GetNamespace()).Update(context.TODO(), ks, updateOpts)
+		if err != nil {
+			return fmt.Errorf("failed to update KnativeServing %q in namespace %q: %w", ks.GetName(), ks.GetNamespace(), err)
+		}
+		ks = updated
+	}
+
+	if output != "" {
+		return printObject(cmd, ks, output)
+	}
+
+	printDomainSet(cmd, domain, selector, dryRun)
+	return nil
+}
+
+// getKnativeServing resolves the KnativeServing resource to operate on. If
+// name is given it's fetched directly (defaulting namespace to
+// knative-serving); otherwise all KnativeServing resources in namespace
+// (or, if empty, all namespaces) are listed and exactly one must exist.
+func getKnativeServing(dc dynamic.Interface, name, namespace string) (*unstructured.Unstructured, error) {
+	if name != "" {
+		ns := namespace
+		if ns == "" {
+			ns = knativeServing
+		}
+		ks, err := dc.Resource(knativeServingResource).Namespace(ns).Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get KnativeServing %q in namespace %q: %w", name, ns, err)
+		}
+		return ks, nil
+	}
+
+	ns := namespace
+	if ns == "" {
+		ns = metav1.NamespaceAll
+	}
+	list, err := dc.Resource(knativeServingResource).Namespace(ns).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list KnativeServing resources: %w", err)
+	}
+
+	switch len(list.Items) {
+	case 0:
+		return nil, fmt.Errorf("no KnativeServing resource found, use --knative-serving-name/--knative-serving-namespace to specify one")
+	case 1:
+		return &list.Items[0], nil
+	default:
+		return nil, fmt.Errorf("multiple KnativeServing resources found, use --knative-serving-name/--knative-serving-namespace to select one")
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2020 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// buildSelector combines the `--selector` values and, if given, the
+// `--selector-from-file` file into a single label selector, rejecting any
+// key that's defined more than once.
+func buildSelector(selectors []string, fromFile string) (map[string]string, error) {
+	selector, err := parseSelectors(selectors)
+	if err != nil {
+		return nil, err
+	}
+
+	if fromFile == "" {
+		return selector, nil
+	}
+
+	fileSelector, err := selectorsFromFile(fromFile)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range fileSelector {
+		if _, ok := selector[k]; ok {
+			return nil, fmt.Errorf("duplicate selector key %q", k)
+		}
+		selector[k] = v
+	}
+
+	return selector, nil
+}
+
+// parseSelectors parses the `--selector` flag values into a single map.
+// Each value may hold several comma-separated "key=value" pairs, and the
+// flag itself is repeatable; a key defined more than once across either is
+// rejected rather than silently overwritten.
+func parseSelectors(selectors []string) (map[string]string, error) {
+	selector := map[string]string{}
+	for _, s := range selectors {
+		for _, pair := range strings.Split(s, ",") {
+			if strings.TrimSpace(pair) == "" {
+				continue
+			}
+
+			k, v, err := splitByEqualSign(pair)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := selector[k]; ok {
+				return nil, fmt.Errorf("duplicate selector key %q", k)
+			}
+			selector[k] = v
+		}
+	}
+	return selector, nil
+}
+
+// selectorsFromFile reads a flat "key: value" YAML mapping from path, for
+// the `--selector-from-file` flag.
+func selectorsFromFile(path string) (map[string]string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read --selector-from-file %q: %w", path, err)
+	}
+
+	var selector map[string]string
+	if err := yaml.Unmarshal(b, &selector); err != nil {
+		return nil, fmt.Errorf("failed to parse --selector-from-file %q: %w", path, err)
+	}
+	return selector, nil
+}
+
+// splitByEqualSign splits a "key=value" pair, rejecting anything that
+// doesn't have exactly one '=' with non-empty trimmed key and value.
+func splitByEqualSign(pair string) (string, string, error) {
+	pair = strings.TrimSpace(pair)
+	parts := strings.Split(pair, "=")
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("expecting the selector format 'name=value', found '%s'", pair)
+	}
+
+	k := strings.TrimSpace(parts[0])
+	v := strings.TrimSpace(parts[1])
+	if k == "" || v == "" {
+		return "", "", fmt.Errorf("expecting the selector format 'name=value', found '%s'", pair)
+	}
+
+	return k, v, nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2020 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func Test_splitByEqualSign(t *testing.T) {
+	tests := []struct {
+		name    string
+		pair    string
+		k       string
+		v       string
+		wantErr bool
+	}{
+		{"normal case", "app=abc", "app", "abc", false},
+		{"normal case with spaces", " app=abc ", "app", "abc", false},
+		{"empty key and value", "=", "", "", true},
+		{"space key and value", " = ", "", "", true},
+		{"empty key 1", "=abc", "", "", true},
+		{"empty key 2", " =abc", "", "", true},
+		{"empty value 1", "app=", "", "", true},
+		{"empty value 2", "app= ", "", "", true},
+		{"invalid input 1", "app=aaa=bbb", "", "", true},
+		{"invalid input 2", "app.123", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotk, gotv, err := splitByEqualSign(tt.pair)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("splitByEqualSign() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotk != tt.k {
+				t.Errorf("splitByEqualSign() got = %v, want %v", gotk, tt.k)
+			}
+			if gotv != tt.v {
+				t.Errorf("splitByEqualSign() got1 = %v, want %v", gotv, tt.v)
+			}
+		})
+	}
+}
+
+func Test_parseSelectors(t *testing.T) {
+	t.Run("merges repeated flags and comma-separated pairs", func(t *testing.T) {
+		got, err := parseSelectors([]string{"app=foo,team=eng", "env=prod"})
+		assert.NilError(t, err)
+		assert.DeepEqual(t, got, map[string]string{"app": "foo", "team": "eng", "env": "prod"})
+	})
+
+	t.Run("no selectors yields an empty map", func(t *testing.T) {
+		got, err := parseSelectors(nil)
+		assert.NilError(t, err)
+		assert.Equal(t, len(got), 0)
+	})
+
+	t.Run("rejects a duplicate key across flags", func(t *testing.T) {
+		_, err := parseSelectors([]string{"app=foo", "app=bar"})
+		assert.ErrorContains(t, err, `duplicate selector key "app"`)
+	})
+
+	t.Run("rejects a duplicate key within the same comma-separated flag", func(t *testing.T) {
+		_, err := parseSelectors([]string{"app=foo,app=bar"})
+		assert.ErrorContains(t, err, `duplicate selector key "app"`)
+	})
+
+	t.Run("propagates a malformed pair", func(t *testing.T) {
+		_, err := parseSelectors([]string{"app"})
+		assert.ErrorContains(t, err, "expecting the selector format 'name=value'")
+	})
+}
+
+func Test_buildSelector(t *testing.T) {
+	t.Run("combines --selector and --selector-from-file", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "selectors.yaml")
+		assert.NilError(t, os.WriteFile(path, []byte("env: prod\n"), 0o600))
+
+		got, err := buildSelector([]string{"app=foo"}, path)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, got, map[string]string{"app": "foo", "env": "prod"})
+	})
+
+	t.Run("rejects a key present in both --selector and the file", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "selectors.yaml")
+		assert.NilError(t, os.WriteFile(path, []byte("app: bar\n"), 0o600))
+
+		_, err := buildSelector([]string{"app=foo"}, path)
+		assert.ErrorContains(t, err, `duplicate selector key "app"`)
+	})
+
+	t.Run("missing file is reported", func(t *testing.T) {
+		_, err := buildSelector(nil, filepath.Join(t.TempDir(), "missing.yaml"))
+		assert.ErrorContains(t, err, "failed to read --selector-from-file")
+	})
+}
@@ -0,0 +1,222 @@
+// Copyright 2020 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/kn-plugin-admin/pkg"
+)
+
+const (
+	configDomain   = "config-domain"
+	knativeServing = "knative-serving"
+)
+
+// domainConfig mirrors the YAML value Knative Serving expects for a routed
+// domain entry, both in config-domain and in the KnativeServing CR's
+// spec.config.domain.
+type domainConfig struct {
+	Selector map[string]string `yaml:"selector,omitempty"`
+}
+
+// NewDomainSetCommand creates the `domain set` command, which sets or
+// updates a custom domain, optionally scoped to routes matching a label
+// selector.
+func NewDomainSetCommand(p *pkg.AdminParams) *cobra.Command {
+	var (
+		customDomain       string
+		selectors          []string
+		selectorFromFile   string
+		knativeServingName string
+		knativeServingNS   string
+		dryRun             string
+		output             string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Set the custom domain for Knative route",
+		Example: `
+  # Set the custom domain to "example.com" for all routes
+  kn admin domain set --custom-domain example.com
+
+  # Set the custom domain to "example.com" only for routes matching the label selector "app=foo"
+  kn admin domain set --custom-domain example.com --selector app=foo
+
+  # Target routes matching multiple labels, either repeating the flag or comma-separating
+  kn admin domain set --custom-domain example.com --selector app=foo --selector env=prod
+  kn admin domain set --custom-domain example.com --selector app=foo,env=prod
+
+  # Load the selector from a YAML file holding a flat key/value mapping
+  kn admin domain set --custom-domain example.com --selector-from-file selectors.yaml
+
+  # Review the resulting object without applying it
+  kn admin domain set --custom-domain example.com --dry-run=client -o yaml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if customDomain == "" {
+				return fmt.Errorf("requires the route name, please use '--custom-domain' to provide the name")
+			}
+
+			dryRun, err := normalizeDryRun(dryRun)
+			if err != nil {
+				return err
+			}
+			if err := validateOutput(output); err != nil {
+				return err
+			}
+
+			sel, err := buildSelector(selectors, selectorFromFile)
+			if err != nil {
+				return err
+			}
+
+			if p.InstallationMethod == pkg.InstallationMethodOperator {
+				return setDomainOperator(p, cmd, customDomain, sel, knativeServingName, knativeServingNS, dryRun, output)
+			}
+			return setDomainConfigMap(p, cmd, customDomain, sel, dryRun, output)
+		},
+	}
+
+	cmd.Flags().StringVar(&customDomain, "custom-domain", "", "The custom domain to route to, e.g. example.com")
+	cmd.Flags().StringArrayVar(&selectors, "selector", nil, "Only apply the custom domain to routes matching this label selector, e.g. app=foo. Repeatable, and each value may hold several comma-separated pairs")
+	cmd.Flags().StringVar(&selectorFromFile, "selector-from-file", "", "Load the label selector from a YAML file holding a flat key/value mapping, e.g. for GitOps workflows")
+	cmd.Flags().StringVar(&knativeServingName, "knative-serving-name", "", "Name of the KnativeServing resource to update (operator installs only); auto-detected if there is exactly one")
+	cmd.Flags().StringVar(&knativeServingNS, "knative-serving-namespace", "", "Namespace of the KnativeServing resource to update (operator installs only); if unset, defaults to knative-serving when --knative-serving-name is given and otherwise searches all namespaces")
+	cmd.Flags().StringVar(&dryRun, "dry-run", "none", `Don't apply the change: "client" reads the current object but sends no update to the API server, "server" submits it with the server-side dry-run flag`)
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Print the resulting object instead of a confirmation message: yaml or json")
+
+	registerCustomDomainCompletion(cmd, p)
+	registerSelectorCompletion(cmd, p)
+
+	return cmd
+}
+
+// setDomainConfigMap applies domain/selector to the config-domain
+// ConfigMap, merging it with any existing entries and skipping the update
+// entirely if the value is unchanged.
+func setDomainConfigMap(p *pkg.AdminParams, cmd *cobra.Command, domain string, selector map[string]string, dryRun, output string) error {
+	client, err := p.ClientSet()
+	if err != nil {
+		return err
+	}
+
+	cm, err := client.CoreV1().ConfigMaps(knativeServing).Get(context.TODO(), configDomain, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get ConfigMap %q in namespace %q: %w", configDomain, knativeServing, err)
+	}
+
+	value, err := domainValue(selector)
+	if err != nil {
+		return err
+	}
+
+	if existing, ok := cm.Data[domain]; ok && existing == value {
+		return nil
+	}
+
+	if len(selector) == 0 {
+		// A domain with no selector is the cluster's default domain, so
+		// setting one replaces whatever default was previously configured
+		// rather than piling up alongside it. Selector-scoped domains are
+		// additive and are kept.
+		for d, v := range cm.Data {
+			if v == "" {
+				delete(cm.Data, d)
+			}
+		}
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[domain] = value
+
+	if dryRun != dryRunClient {
+		updateOpts := metav1.UpdateOptions{}
+		if dryRun == dryRunServer {
+			updateOpts.DryRun = []string{metav1.DryRunAll}
+		}
+		updated, err := client.CoreV1().ConfigMaps(knativeServing).Update(context.TODO(), cm, updateOpts)
+		if err != nil {
+			return fmt.Errorf("failed to update ConfigMap %q in namespace %q: %w", configDomain, knativeServing, err)
+		}
+		cm = updated
+	}
+
+	if output != "" {
+		cm.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"}
+		return printObject(cmd, cm, output)
+	}
+
+	printDomainSet(cmd, domain, selector, dryRun)
+	return nil
+}
+
+// domainValue renders the config-domain value for a (possibly empty)
+// selector, matching the `selector:` shape domainConfig/domainSelector
+// expect on read-back.
+func domainValue(selector map[string]string) (string, error) {
+	if len(selector) == 0 {
+		return "", nil
+	}
+
+	b, err := yaml.Marshal(domainConfig{Selector: selector})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal selector: %w", err)
+	}
+	return string(b), nil
+}
+
+// printDomainSet writes the user-facing confirmation for a successful set,
+// shared by both the ConfigMap and operator code paths. When dryRun is not
+// dryRunNone, it makes clear that nothing was actually applied, so GitOps
+// users can't mistake it for a real write.
+func printDomainSet(cmd *cobra.Command, domain string, selector map[string]string, dryRun string) {
+	verb := "Set"
+	suffix := ""
+	if dryRun != dryRunNone {
+		verb = "Would set"
+		suffix = " (dry run)"
+	}
+
+	if len(selector) == 0 {
+		cmd.Printf("%s knative route domain %q%s\n", verb, domain, suffix)
+		return
+	}
+	cmd.Printf("%s knative route domain %q with selector %s%s\n", verb, domain, formatSelector(selector), suffix)
+}
+
+// formatSelector renders a selector as "[k1=v1,k2=v2]" with keys sorted for
+// stable output.
+func formatSelector(selector map[string]string) string {
+	keys := make([]string, 0, len(selector))
+	for k := range selector {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, selector[k]))
+	}
+	return "[" + strings.Join(pairs, ",") + "]"
+}
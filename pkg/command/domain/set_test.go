@@ -26,8 +26,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"knative.dev/kn-plugin-admin/pkg"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	sigsyaml "sigs.k8s.io/yaml"
 
+	"knative.dev/kn-plugin-admin/pkg"
 	"knative.dev/kn-plugin-admin/pkg/testutil"
 )
 
@@ -35,6 +37,25 @@ type domainSelector struct {
 	Selector map[string]string `yaml:"selector,omitempty"`
 }
 
+// newKnativeServing builds a minimal KnativeServing resource with the given
+// spec.config.domain entries, as used by the operator-mode test cases.
+func newKnativeServing(name, namespace string, domain map[string]string) *unstructured.Unstructured {
+	ks := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "operator.knative.dev/v1beta1",
+			"kind":       "KnativeServing",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": namespace,
+			},
+		},
+	}
+	if len(domain) > 0 {
+		_ = unstructured.SetNestedStringMap(ks.Object, domain, "spec", "config", "domain")
+	}
+	return ks
+}
+
 func executeCommandC(root *cobra.Command, args ...string) (c *cobra.Command, output string, err error) {
 	buf := new(bytes.Buffer)
 	root.SetOut(buf)
@@ -71,21 +92,67 @@ func TestNewDomainSetCommand(t *testing.T) {
 		assert.ErrorContains(t, err, "requires the route name", err)
 	})
 
-	t.Run("operator mode should not be supported", func(t *testing.T) {
-		cm := &corev1.ConfigMap{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      configDomain,
-				Namespace: knativeServing,
-			},
-			Data: make(map[string]string),
-		}
-		p, client := testutil.NewTestAdminParams(cm)
-		assert.Check(t, client != nil)
-		p.InstallationMethod = pkg.InstallationMethodOperator
+	t.Run("operator mode with no KnativeServing resource", func(t *testing.T) {
+		p, _ := testutil.NewTestAdminParamsWithKnativeServing()
 		cmd := NewDomainSetCommand(p)
 
 		_, err := testutil.ExecuteCommand(cmd, "--custom-domain", "test.domain")
-		assert.ErrorContains(t, err, "Knative managed by operator is not supported yet", err)
+		assert.ErrorContains(t, err, "no KnativeServing resource found", err)
+	})
+
+	t.Run("setting domain config with unchanged value for operator mode", func(t *testing.T) {
+		ks := newKnativeServing("knative-serving", knativeServing, map[string]string{"test.domain": ""})
+		p, dynamicClient := testutil.NewTestAdminParamsWithKnativeServing(ks)
+		cmd := NewDomainSetCommand(p)
+
+		_, err := testutil.ExecuteCommand(cmd, "--custom-domain", "test.domain")
+		assert.NilError(t, err)
+
+		updated, err := dynamicClient.Resource(knativeServingResource).Namespace(knativeServing).Get(context.TODO(), "knative-serving", metav1.GetOptions{})
+		assert.NilError(t, err)
+		assert.Check(t, equality.Semantic.DeepEqual(updated, ks), "KnativeServing should not have changed")
+	})
+
+	t.Run("adding domain config with selector for operator mode", func(t *testing.T) {
+		ks := newKnativeServing("knative-serving", knativeServing, map[string]string{"foo.bar": ""})
+		p, dynamicClient := testutil.NewTestAdminParamsWithKnativeServing(ks)
+		cmd := NewDomainSetCommand(p)
+
+		o, err := testutil.ExecuteCommand(cmd, "--custom-domain", "test.domain", "--selector", "app=test")
+		assert.NilError(t, err)
+		assert.Check(t, strings.Contains(o, "Set knative route domain \"test.domain\" with selector [app=test]"), "invalid output %q", o)
+
+		updated, err := dynamicClient.Resource(knativeServingResource).Namespace(knativeServing).Get(context.TODO(), "knative-serving", metav1.GetOptions{})
+		assert.NilError(t, err)
+
+		domainCfg, _, err := unstructured.NestedStringMap(updated.Object, "spec", "config", "domain")
+		assert.NilError(t, err)
+
+		var s domainSelector
+		err = yaml.Unmarshal([]byte(domainCfg["test.domain"]), &s)
+		assert.NilError(t, err)
+		assert.Equal(t, "test", s.Selector["app"])
+	})
+
+	t.Run("adding domain config without selector with existing domain configuration for operator mode", func(t *testing.T) {
+		ks := newKnativeServing("knative-serving", knativeServing, map[string]string{"foo.bar": ""})
+		p, dynamicClient := testutil.NewTestAdminParamsWithKnativeServing(ks)
+		cmd := NewDomainSetCommand(p)
+
+		o, err := testutil.ExecuteCommand(cmd, "--custom-domain", "test.domain")
+		assert.NilError(t, err)
+		assert.Check(t, strings.Contains(o, "Set knative route domain \"test.domain\""), "expected update information in standard output")
+
+		updated, err := dynamicClient.Resource(knativeServingResource).Namespace(knativeServing).Get(context.TODO(), "knative-serving", metav1.GetOptions{})
+		assert.NilError(t, err)
+
+		domainCfg, _, err := unstructured.NestedStringMap(updated.Object, "spec", "config", "domain")
+		assert.NilError(t, err)
+		assert.Check(t, len(domainCfg) == 1, "expected domain config length to be 1, actual %d", len(domainCfg))
+
+		v, ok := domainCfg["test.domain"]
+		assert.Check(t, ok, "domain key %q should exists", "test.domain")
+		assert.Equal(t, "", v, "value of key domain should be empty")
 	})
 
 	t.Run("config map not exist", func(t *testing.T) {
@@ -208,6 +275,72 @@ func TestNewDomainSetCommand(t *testing.T) {
 		assert.Equal(t, "test", v)
 	})
 
+	t.Run("dry-run=client does not update the ConfigMap and prints it as YAML", func(t *testing.T) {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      configDomain,
+				Namespace: knativeServing,
+			},
+			Data: map[string]string{
+				"foo.bar": "",
+			},
+		}
+		p, client := testutil.NewTestAdminParams(cm)
+		assert.Check(t, client != nil)
+		cmd := NewDomainSetCommand(p)
+
+		o, err := testutil.ExecuteCommand(cmd, "--custom-domain", "test.domain", "--selector", "app=test", "--dry-run", "client", "-o", "yaml")
+		assert.NilError(t, err)
+
+		var printed corev1.ConfigMap
+		assert.NilError(t, sigsyaml.Unmarshal([]byte(o), &printed))
+		assert.Check(t, strings.Contains(printed.Data["test.domain"], "app: test"), "expected printed ConfigMap to contain the new key, got %q", o)
+		assert.Equal(t, "v1", printed.APIVersion, "printed ConfigMap should be a valid, re-appliable manifest")
+		assert.Equal(t, "ConfigMap", printed.Kind, "printed ConfigMap should be a valid, re-appliable manifest")
+
+		unchanged, err := client.CoreV1().ConfigMaps(knativeServing).Get(context.TODO(), configDomain, metav1.GetOptions{})
+		assert.NilError(t, err)
+		assert.Check(t, equality.Semantic.DeepEqual(unchanged, cm), "configmap should not have been updated under --dry-run=client")
+	})
+
+	t.Run("dry-run=client without -o prints a dry-run confirmation, not a real apply", func(t *testing.T) {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      configDomain,
+				Namespace: knativeServing,
+			},
+			Data: make(map[string]string),
+		}
+		p, client := testutil.NewTestAdminParams(cm)
+		cmd := NewDomainSetCommand(p)
+
+		o, err := testutil.ExecuteCommand(cmd, "--custom-domain", "test.domain", "--dry-run", "client")
+		assert.NilError(t, err)
+		assert.Check(t, strings.Contains(o, "(dry run)"), "expected output to call out that this was a dry run, got %q", o)
+
+		unchanged, err := client.CoreV1().ConfigMaps(knativeServing).Get(context.TODO(), configDomain, metav1.GetOptions{})
+		assert.NilError(t, err)
+		assert.Check(t, equality.Semantic.DeepEqual(unchanged, cm), "configmap should not have been updated under --dry-run=client")
+	})
+
+	t.Run("invalid --dry-run value is rejected", func(t *testing.T) {
+		p, client := testutil.NewTestAdminParams()
+		assert.Check(t, client != nil)
+		cmd := NewDomainSetCommand(p)
+
+		_, err := testutil.ExecuteCommand(cmd, "--custom-domain", "test.domain", "--dry-run", "bogus")
+		assert.ErrorContains(t, err, `unsupported --dry-run value "bogus"`, err)
+	})
+
+	t.Run("invalid output format is rejected", func(t *testing.T) {
+		p, client := testutil.NewTestAdminParams()
+		assert.Check(t, client != nil)
+		cmd := NewDomainSetCommand(p)
+
+		_, err := testutil.ExecuteCommand(cmd, "--custom-domain", "test.domain", "-o", "bogus")
+		assert.ErrorContains(t, err, `unsupported output format "bogus"`, err)
+	})
+
 	t.Run("adding domain config with invalid selector", func(t *testing.T) {
 		cm := &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
@@ -226,40 +359,74 @@ func TestNewDomainSetCommand(t *testing.T) {
 		_, err := testutil.ExecuteCommand(cmd, "--custom-domain", "test.domain", "--selector", "app")
 		assert.ErrorContains(t, err, "expecting the selector format 'name=value', found 'app'", err)
 	})
-}
 
-func Test_splitByEqualSign(t *testing.T) {
-	tests := []struct {
-		name    string
-		pair    string
-		k       string
-		v       string
-		wantErr bool
-	}{
-		{"normal case", "app=abc", "app", "abc", false},
-		{"normal case with spaces", " app=abc ", "app", "abc", false},
-		{"empty key and value", "=", "", "", true},
-		{"space key and value", " = ", "", "", true},
-		{"empty key 1", "=abc", "", "", true},
-		{"empty key 2", " =abc", "", "", true},
-		{"empty value 1", "app=", "", "", true},
-		{"empty value 2", "app= ", "", "", true},
-		{"invalid input 1", "app=aaa=bbb", "", "", true},
-		{"invalid input 2", "app.123", "", "", true},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			gotk, gotv, err := splitByEqualSign(tt.pair)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("splitByEqualSign() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if gotk != tt.k {
-				t.Errorf("splitByEqualSign() got = %v, want %v", gotk, tt.k)
-			}
-			if gotv != tt.v {
-				t.Errorf("splitByEqualSign() got1 = %v, want %v", gotv, tt.v)
-			}
-		})
-	}
+	t.Run("setting domain config with repeated and comma-separated selectors", func(t *testing.T) {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      configDomain,
+				Namespace: knativeServing,
+			},
+			Data: make(map[string]string),
+		}
+		p, client := testutil.NewTestAdminParams(cm)
+		cmd := NewDomainSetCommand(p)
+
+		_, err := testutil.ExecuteCommand(cmd, "--custom-domain", "test.domain", "--selector", "app=test,team=eng", "--selector", "env=prod")
+		assert.NilError(t, err)
+
+		cm, err = client.CoreV1().ConfigMaps(knativeServing).Get(context.TODO(), configDomain, metav1.GetOptions{})
+		assert.NilError(t, err)
+
+		var s domainSelector
+		err = yaml.Unmarshal([]byte(cm.Data["test.domain"]), &s)
+		assert.NilError(t, err)
+		assert.Equal(t, "test", s.Selector["app"])
+		assert.Equal(t, "eng", s.Selector["team"])
+		assert.Equal(t, "prod", s.Selector["env"])
+	})
+
+	t.Run("setting domain config with a duplicate selector key", func(t *testing.T) {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      configDomain,
+				Namespace: knativeServing,
+			},
+			Data: make(map[string]string),
+		}
+		p, client := testutil.NewTestAdminParams(cm)
+		assert.Check(t, client != nil)
+		cmd := NewDomainSetCommand(p)
+
+		_, err := testutil.ExecuteCommand(cmd, "--custom-domain", "test.domain", "--selector", "app=foo", "--selector", "app=bar")
+		assert.ErrorContains(t, err, `duplicate selector key "app"`, err)
+	})
+
+	t.Run("re-setting domain config overwrites the selector on the same domain key", func(t *testing.T) {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      configDomain,
+				Namespace: knativeServing,
+			},
+			Data: make(map[string]string),
+		}
+		p, client := testutil.NewTestAdminParams(cm)
+		cmd := NewDomainSetCommand(p)
+
+		_, err := testutil.ExecuteCommand(cmd, "--custom-domain", "test.domain", "--selector", "app=old")
+		assert.NilError(t, err)
+
+		cmd = NewDomainSetCommand(p)
+		_, err = testutil.ExecuteCommand(cmd, "--custom-domain", "test.domain", "--selector", "app=new")
+		assert.NilError(t, err)
+
+		cm, err = client.CoreV1().ConfigMaps(knativeServing).Get(context.TODO(), configDomain, metav1.GetOptions{})
+		assert.NilError(t, err)
+		assert.Check(t, len(cm.Data) == 1, "expected configmap lengh to be 1, actual %d", len(cm.Data))
+
+		var s domainSelector
+		err = yaml.Unmarshal([]byte(cm.Data["test.domain"]), &s)
+		assert.NilError(t, err)
+		assert.Check(t, len(s.Selector) == 1, "selector should only contain one key-value pair, got %+v", s.Selector)
+		assert.Equal(t, "new", s.Selector["app"])
+	})
 }
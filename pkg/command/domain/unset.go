@@ -0,0 +1,124 @@
+// Copyright 2020 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"knative.dev/kn-plugin-admin/pkg"
+)
+
+// NewDomainUnsetCommand creates the `domain unset` command, which removes a
+// previously configured custom domain.
+func NewDomainUnsetCommand(p *pkg.AdminParams) *cobra.Command {
+	var (
+		customDomain       string
+		knativeServingName string
+		knativeServingNS   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "unset",
+		Short: "Unset a custom domain for Knative route",
+		Example: `
+  # Remove the custom domain "example.com"
+  kn admin domain unset --custom-domain example.com`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if customDomain == "" {
+				return fmt.Errorf("requires the route name, please use '--custom-domain' to provide the name")
+			}
+
+			if p.InstallationMethod == pkg.InstallationMethodOperator {
+				return unsetDomainOperator(p, cmd, customDomain, knativeServingName, knativeServingNS)
+			}
+			return unsetDomainConfigMap(p, cmd, customDomain)
+		},
+	}
+
+	cmd.Flags().StringVar(&customDomain, "custom-domain", "", "The custom domain to remove, e.g. example.com")
+	cmd.Flags().StringVar(&knativeServingName, "knative-serving-name", "", "Name of the KnativeServing resource to update (operator installs only); auto-detected if there is exactly one")
+	cmd.Flags().StringVar(&knativeServingNS, "knative-serving-namespace", "", "Namespace of the KnativeServing resource to update (operator installs only); if unset, defaults to knative-serving when --knative-serving-name is given and otherwise searches all namespaces")
+
+	registerCustomDomainCompletion(cmd, p)
+
+	return cmd
+}
+
+// unsetDomainConfigMap removes domain from the config-domain ConfigMap,
+// doing nothing if it isn't present.
+func unsetDomainConfigMap(p *pkg.AdminParams, cmd *cobra.Command, domain string) error {
+	client, err := p.ClientSet()
+	if err != nil {
+		return err
+	}
+
+	cm, err := client.CoreV1().ConfigMaps(knativeServing).Get(context.TODO(), configDomain, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get ConfigMap %q in namespace %q: %w", configDomain, knativeServing, err)
+	}
+
+	if _, ok := cm.Data[domain]; !ok {
+		return nil
+	}
+	delete(cm.Data, domain)
+
+	if _, err := client.CoreV1().ConfigMaps(knativeServing).Update(context.TODO(), cm, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update ConfigMap %q in namespace %q: %w", configDomain, knativeServing, err)
+	}
+
+	cmd.Printf("Unset knative route domain %q\n", domain)
+	return nil
+}
+
+// unsetDomainOperator removes domain from the spec.config.domain of the
+// KnativeServing resource selected by name/namespace, doing nothing if it
+// isn't present.
+func unsetDomainOperator(p *pkg.AdminParams, cmd *cobra.Command, domain, name, namespace string) error {
+	dc, err := p.DynamicClient()
+	if err != nil {
+		return err
+	}
+
+	ks, err := getKnativeServing(dc, name, namespace)
+	if err != nil {
+		return err
+	}
+
+	domainCfg, _, err := unstructured.NestedStringMap(ks.Object, "spec", "config", "domain")
+	if err != nil {
+		return fmt.Errorf("failed to read spec.config.domain of KnativeServing %q: %w", ks.GetName(), err)
+	}
+
+	if _, ok := domainCfg[domain]; !ok {
+		return nil
+	}
+	delete(domainCfg, domain)
+
+	if err := unstructured.SetNestedStringMap(ks.Object, domainCfg, "spec", "config", "domain"); err != nil {
+		return fmt.Errorf("failed to set spec.config.domain of KnativeServing %q: %w", ks.GetName(), err)
+	}
+
+	if _, err := dc.Resource(knativeServingResource).Namespace(ks.GetNamespace()).Update(context.TODO(), ks, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update KnativeServing %q in namespace %q: %w", ks.GetName(), ks.GetNamespace(), err)
+	}
+
+	cmd.Printf("Unset knative route domain %q\n", domain)
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2020 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"knative.dev/kn-plugin-admin/pkg"
+
+	"knative.dev/kn-plugin-admin/pkg/testutil"
+)
+
+func TestNewDomainUnsetCommand(t *testing.T) {
+
+	t.Run("kubectl context is not set", func(t *testing.T) {
+		p := testutil.NewTestAdminWithoutKubeConfig()
+		p.InstallationMethod = pkg.InstallationMethodStandalone
+		cmd := NewDomainUnsetCommand(p)
+		_, err := testutil.ExecuteCommand(cmd, "--custom-domain", "test.domain")
+		assert.Error(t, err, testutil.ErrNoKubeConfiguration)
+	})
+
+	t.Run("incompleted args", func(t *testing.T) {
+		p, client := testutil.NewTestAdminParams()
+		assert.Check(t, client != nil)
+		cmd := NewDomainUnsetCommand(p)
+
+		_, err := testutil.ExecuteCommand(cmd, "--custom-domain", "")
+		assert.ErrorContains(t, err, "requires the route name", err)
+	})
+
+	t.Run("config map not exist", func(t *testing.T) {
+		p, client := testutil.NewTestAdminParams()
+		assert.Check(t, client != nil)
+		cmd := NewDomainUnsetCommand(p)
+		_, err := testutil.ExecuteCommand(cmd, "--custom-domain", "test.domain")
+		assert.ErrorContains(t, err, "failed to get ConfigMap", err)
+	})
+
+	t.Run("removing a non-existent domain is a no-op", func(t *testing.T) {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      configDomain,
+				Namespace: knativeServing,
+			},
+			Data: map[string]string{
+				"foo.bar": "",
+			},
+		}
+		p, client := testutil.NewTestAdminParams(cm)
+		cmd := NewDomainUnsetCommand(p)
+
+		_, err := testutil.ExecuteCommand(cmd, "--custom-domain", "test.domain")
+		assert.NilError(t, err)
+
+		updated, err := client.CoreV1().ConfigMaps(knativeServing).Get(context.TODO(), configDomain, metav1.GetOptions{})
+		assert.NilError(t, err)
+		assert.Check(t, equality.Semantic.DeepEqual(updated, cm), "configmap should not have changed")
+	})
+
+	t.Run("removing an existing domain", func(t *testing.T) {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      configDomain,
+				Namespace: knativeServing,
+			},
+			Data: map[string]string{
+				"foo.bar":     "",
+				"test.domain": "",
+			},
+		}
+		p, client := testutil.NewTestAdminParams(cm)
+		cmd := NewDomainUnsetCommand(p)
+
+		o, err := testutil.ExecuteCommand(cmd, "--custom-domain", "test.domain")
+		assert.NilError(t, err)
+		assert.Check(t, strings.Contains(o, "Unset knative route domain \"test.domain\""), "invalid output %q", o)
+
+		updated, err := client.CoreV1().ConfigMaps(knativeServing).Get(context.TODO(), configDomain, metav1.GetOptions{})
+		assert.NilError(t, err)
+		_, ok := updated.Data["test.domain"]
+		assert.Check(t, !ok, "domain key %q should have been removed", "test.domain")
+		_, ok = updated.Data["foo.bar"]
+		assert.Check(t, ok, "domain key %q should still exist", "foo.bar")
+	})
+
+	t.Run("operator mode removing an existing domain", func(t *testing.T) {
+		ks := newKnativeServing("knative-serving", knativeServing, map[string]string{
+			"foo.bar":     "",
+			"test.domain": "",
+		})
+		p, dynamicClient := testutil.NewTestAdminParamsWithKnativeServing(ks)
+		cmd := NewDomainUnsetCommand(p)
+
+		_, err := testutil.ExecuteCommand(cmd, "--custom-domain", "test.domain")
+		assert.NilError(t, err)
+
+		updated, err := dynamicClient.Resource(knativeServingResource).Namespace(knativeServing).Get(context.TODO(), "knative-serving", metav1.GetOptions{})
+		assert.NilError(t, err)
+
+		domainCfg, _, err := unstructured.NestedStringMap(updated.Object, "spec", "config", "domain")
+		assert.NilError(t, err)
+		_, ok := domainCfg["test.domain"]
+		assert.Check(t, !ok, "domain key %q should have been removed", "test.domain")
+	})
+}
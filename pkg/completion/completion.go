@@ -0,0 +1,112 @@
+// Copyright 2020 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package completion provides shell completion helpers for `kn admin`
+// subcommands: given a pkg.AdminParams, they resolve the right client and
+// turn live cluster state into cobra completion suggestions.
+package completion
+
+import (
+	"context"
+	"sort"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"knative.dev/kn-plugin-admin/pkg"
+)
+
+const (
+	configDomain   = "config-domain"
+	knativeServing = "knative-serving"
+)
+
+// knativeServingResource is the GroupVersionResource of the operator's
+// KnativeServing custom resource.
+var knativeServingResource = schema.GroupVersionResource{
+	Group:    "operator.knative.dev",
+	Version:  "v1beta1",
+	Resource: "knativeservings",
+}
+
+// CustomDomains completes `--custom-domain` with the domain keys already
+// present in config-domain (standalone installs) or the KnativeServing
+// resource (operator installs).
+func CustomDomains(p *pkg.AdminParams) ([]string, cobra.ShellCompDirective) {
+	var data map[string]string
+
+	if p.InstallationMethod == pkg.InstallationMethodOperator {
+		dc, err := p.DynamicClient()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		list, err := dc.Resource(knativeServingResource).Namespace(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{})
+		if err != nil || len(list.Items) != 1 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		data, _, _ = unstructured.NestedStringMap(list.Items[0].Object, "spec", "config", "domain")
+	} else {
+		client, err := p.ClientSet()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		cm, err := client.CoreV1().ConfigMaps(knativeServing).Get(context.TODO(), configDomain, metav1.GetOptions{})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		data = cm.Data
+	}
+
+	domains := make([]string, 0, len(data))
+	for d := range data {
+		domains = append(domains, d)
+	}
+	sort.Strings(domains)
+
+	return domains, cobra.ShellCompDirectiveNoFileComp
+}
+
+// SelectorKeys completes the key half of `--selector key=value` with the
+// distinct label keys found on Knative Services in the cluster.
+func SelectorKeys(p *pkg.AdminParams) ([]string, cobra.ShellCompDirective) {
+	client, err := p.ServingClient()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	list, err := client.ServingV1().Services(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	seen := map[string]struct{}{}
+	keys := make([]string, 0)
+	for _, svc := range list.Items {
+		for k := range svc.Labels {
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
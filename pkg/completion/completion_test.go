@@ -0,0 +1,123 @@
+// Copyright 2020 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package completion
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+
+	"knative.dev/kn-plugin-admin/pkg/testutil"
+)
+
+// newKnativeServing builds a minimal KnativeServing resource with the given
+// spec.config.domain entries, mirroring the domain package's own fixture.
+func newKnativeServing(name, namespace string, domain map[string]string) *unstructured.Unstructured {
+	ks := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "operator.knative.dev/v1beta1",
+			"kind":       "KnativeServing",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": namespace,
+			},
+		},
+	}
+	if len(domain) > 0 {
+		_ = unstructured.SetNestedStringMap(ks.Object, domain, "spec", "config", "domain")
+	}
+	return ks
+}
+
+func TestCustomDomains(t *testing.T) {
+
+	t.Run("kubectl context is not set", func(t *testing.T) {
+		p := testutil.NewTestAdminWithoutKubeConfig()
+		domains, directive := CustomDomains(p)
+		assert.Check(t, domains == nil)
+		assert.Equal(t, directive, cobra.ShellCompDirectiveNoFileComp)
+	})
+
+	t.Run("config map not exist", func(t *testing.T) {
+		p, _ := testutil.NewTestAdminParams()
+		domains, directive := CustomDomains(p)
+		assert.Check(t, domains == nil)
+		assert.Equal(t, directive, cobra.ShellCompDirectiveNoFileComp)
+	})
+
+	t.Run("suggests the domains configured in config-domain", func(t *testing.T) {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: configDomain, Namespace: knativeServing},
+			Data:       map[string]string{"b.example.com": "", "a.example.com": ""},
+		}
+		p, _ := testutil.NewTestAdminParams(cm)
+		domains, directive := CustomDomains(p)
+		assert.DeepEqual(t, domains, []string{"a.example.com", "b.example.com"})
+		assert.Equal(t, directive, cobra.ShellCompDirectiveNoFileComp)
+	})
+
+	t.Run("suggests the domains configured on the single KnativeServing", func(t *testing.T) {
+		ks := newKnativeServing("knative-serving", knativeServing, map[string]string{"c.example.com": ""})
+		p, _ := testutil.NewTestAdminParamsWithKnativeServing(ks)
+		domains, directive := CustomDomains(p)
+		assert.DeepEqual(t, domains, []string{"c.example.com"})
+		assert.Equal(t, directive, cobra.ShellCompDirectiveNoFileComp)
+	})
+
+	t.Run("no suggestions when multiple KnativeServing resources exist", func(t *testing.T) {
+		ks1 := newKnativeServing("ks1", "ns1", map[string]string{"c.example.com": ""})
+		ks2 := newKnativeServing("ks2", "ns2", map[string]string{"d.example.com": ""})
+		p, _ := testutil.NewTestAdminParamsWithKnativeServing(ks1, ks2)
+		domains, directive := CustomDomains(p)
+		assert.Check(t, domains == nil)
+		assert.Equal(t, directive, cobra.ShellCompDirectiveNoFileComp)
+	})
+}
+
+func TestSelectorKeys(t *testing.T) {
+
+	t.Run("kubectl context is not set", func(t *testing.T) {
+		p := testutil.NewTestAdminWithoutKubeConfig()
+		keys, directive := SelectorKeys(p)
+		assert.Check(t, keys == nil)
+		assert.Equal(t, directive, cobra.ShellCompDirectiveNoFileComp)
+	})
+
+	t.Run("suggests the distinct label keys found on Knative Services", func(t *testing.T) {
+		svc1 := &servingv1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "svc1",
+				Namespace: "default",
+				Labels:    map[string]string{"app": "foo", "env": "prod"},
+			},
+		}
+		svc2 := &servingv1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "svc2",
+				Namespace: "other",
+				Labels:    map[string]string{"app": "bar"},
+			},
+		}
+		p, _ := testutil.NewTestAdminParamsWithServing(svc1, svc2)
+		keys, directive := SelectorKeys(p)
+		assert.DeepEqual(t, keys, []string{"app", "env"})
+		assert.Equal(t, directive, cobra.ShellCompDirectiveNoFileComp|cobra.ShellCompDirectiveNoSpace)
+	})
+}
@@ -0,0 +1,153 @@
+// Copyright 2020 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pkg holds the state and clients shared across every `kn admin`
+// subcommand.
+package pkg
+
+import (
+	"errors"
+
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	servingclientset "knative.dev/serving/pkg/client/clientset/versioned"
+)
+
+// InstallationMethod describes how Knative Serving was installed onto the
+// target cluster, which in turn determines how admin commands read and
+// write cluster configuration.
+type InstallationMethod string
+
+const (
+	// InstallationMethodStandalone means Knative Serving was installed
+	// directly from the upstream YAML manifests and is configured through
+	// plain ConfigMaps.
+	InstallationMethodStandalone InstallationMethod = "standalone"
+	// InstallationMethodOperator means Knative Serving is managed by the
+	// Knative Operator and is configured through the KnativeServing custom
+	// resource.
+	InstallationMethodOperator InstallationMethod = "operator"
+)
+
+// ErrNoKubeConfiguration is returned by AdminParams.ClientSet/DynamicClient
+// when no kubeconfig could be resolved for the current context.
+const ErrNoKubeConfiguration = "no kubeconfig has been configured for the current context"
+
+// AdminParams carries the dependencies every `kn admin` subcommand needs to
+// talk to a cluster: the kubeconfig to build clients from, the resolved
+// clients themselves (lazily created and cached), and the installation
+// method that decides which of those clients a command should use.
+type AdminParams struct {
+	ClientConfig clientcmd.ClientConfig
+
+	InstallationMethod InstallationMethod
+
+	clientset     kubernetes.Interface
+	dynamicClient dynamic.Interface
+	servingClient servingclientset.Interface
+}
+
+// ClientSet returns the typed Kubernetes client for the configured context,
+// building and caching it from ClientConfig on first use.
+func (params *AdminParams) ClientSet() (kubernetes.Interface, error) {
+	if params.clientset != nil {
+		return params.clientset, nil
+	}
+
+	if params.ClientConfig == nil {
+		return nil, errors.New(ErrNoKubeConfiguration)
+	}
+
+	cfg, err := params.ClientConfig.ClientConfig()
+	if err != nil {
+		return nil, errors.New(ErrNoKubeConfiguration)
+	}
+
+	cs, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	params.clientset = cs
+	return cs, nil
+}
+
+// DynamicClient returns the dynamic client for the configured context,
+// building and caching it from ClientConfig on first use. It is used for
+// custom resources, such as the operator's KnativeServing, that don't have
+// a typed client available here.
+func (params *AdminParams) DynamicClient() (dynamic.Interface, error) {
+	if params.dynamicClient != nil {
+		return params.dynamicClient, nil
+	}
+
+	if params.ClientConfig == nil {
+		return nil, errors.New(ErrNoKubeConfiguration)
+	}
+
+	cfg, err := params.ClientConfig.ClientConfig()
+	if err != nil {
+		return nil, errors.New(ErrNoKubeConfiguration)
+	}
+
+	dc, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	params.dynamicClient = dc
+	return dc, nil
+}
+
+// ServingClient returns the typed Knative Serving client for the
+// configured context, building and caching it from ClientConfig on first
+// use. It's used to inspect Knative Services, e.g. for shell completion.
+func (params *AdminParams) ServingClient() (servingclientset.Interface, error) {
+	if params.servingClient != nil {
+		return params.servingClient, nil
+	}
+
+	if params.ClientConfig == nil {
+		return nil, errors.New(ErrNoKubeConfiguration)
+	}
+
+	cfg, err := params.ClientConfig.ClientConfig()
+	if err != nil {
+		return nil, errors.New(ErrNoKubeConfiguration)
+	}
+
+	sc, err := servingclientset.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	params.servingClient = sc
+	return sc, nil
+}
+
+// SetClientSet overrides the typed client, bypassing ClientConfig. It
+// exists so tests can inject a fake clientset.
+func (params *AdminParams) SetClientSet(clientset kubernetes.Interface) {
+	params.clientset = clientset
+}
+
+// SetDynamicClient overrides the dynamic client, bypassing ClientConfig. It
+// exists so tests can inject a fake dynamic client.
+func (params *AdminParams) SetDynamicClient(client dynamic.Interface) {
+	params.dynamicClient = client
+}
+
+// SetServingClient overrides the serving client, bypassing ClientConfig. It
+// exists so tests can inject a fake serving clientset.
+func (params *AdminParams) SetServingClient(client servingclientset.Interface) {
+	params.servingClient = client
+}
@@ -0,0 +1,107 @@
+// Copyright 2020 The Knative Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package testutil provides helpers shared by the `pkg/command/...` unit
+// tests: building an AdminParams backed by fake clients, and executing a
+// cobra command while capturing its output.
+package testutil
+
+import (
+	"bytes"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	servingfake "knative.dev/serving/pkg/client/clientset/versioned/fake"
+
+	"knative.dev/kn-plugin-admin/pkg"
+)
+
+// ErrNoKubeConfiguration mirrors pkg.ErrNoKubeConfiguration so tests don't
+// need to import pkg just to assert on it.
+const ErrNoKubeConfiguration = pkg.ErrNoKubeConfiguration
+
+// NewTestAdminParams returns an AdminParams backed by a fake Kubernetes
+// clientset seeded with objects, along with that clientset so tests can
+// assert on the resulting cluster state.
+func NewTestAdminParams(objects ...runtime.Object) (*pkg.AdminParams, *fake.Clientset) {
+	client := fake.NewSimpleClientset(objects...)
+
+	p := &pkg.AdminParams{
+		InstallationMethod: pkg.InstallationMethodStandalone,
+	}
+	p.SetClientSet(client)
+
+	return p, client
+}
+
+// NewTestAdminWithoutKubeConfig returns an AdminParams with no kubeconfig
+// and no client set, so that ClientSet/DynamicClient fail the way they
+// would against a cluster with no configured context.
+func NewTestAdminWithoutKubeConfig() *pkg.AdminParams {
+	return &pkg.AdminParams{}
+}
+
+// knativeServingResource is the GroupVersionResource of the operator's
+// KnativeServing custom resource.
+var knativeServingResource = schema.GroupVersionResource{
+	Group:    "operator.knative.dev",
+	Version:  "v1beta1",
+	Resource: "knativeservings",
+}
+
+// NewTestAdminParamsWithKnativeServing returns an AdminParams in operator
+// mode backed by a fake dynamic client seeded with objects, along with that
+// client so tests can assert on the resulting KnativeServing state.
+func NewTestAdminParamsWithKnativeServing(objects ...runtime.Object) (*pkg.AdminParams, *dynamicfake.FakeDynamicClient) {
+	scheme := runtime.NewScheme()
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, map[schema.GroupVersionResource]string{
+		knativeServingResource: "KnativeServingList",
+	}, objects...)
+
+	p := &pkg.AdminParams{
+		InstallationMethod: pkg.InstallationMethodOperator,
+	}
+	p.SetDynamicClient(client)
+
+	return p, client
+}
+
+// NewTestAdminParamsWithServing returns an AdminParams backed by a fake
+// Knative Serving clientset seeded with objects, along with that clientset
+// so tests can assert against it.
+func NewTestAdminParamsWithServing(objects ...runtime.Object) (*pkg.AdminParams, *servingfake.Clientset) {
+	client := servingfake.NewSimpleClientset(objects...)
+
+	p := &pkg.AdminParams{
+		InstallationMethod: pkg.InstallationMethodStandalone,
+	}
+	p.SetServingClient(client)
+
+	return p, client
+}
+
+// ExecuteCommand runs cmd with args, capturing and returning everything it
+// writes to stdout/stderr together with any error it returns.
+func ExecuteCommand(cmd *cobra.Command, args ...string) (string, error) {
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return buf.String(), err
+}